@@ -10,50 +10,179 @@ import (
 )
 
 const (
-	defaultCSVTagName     = "csv"
-	defaultCSVTagFieldSep = ","
+	defaultCSVTagName      = "csv"
+	defaultCSVTagFieldSep  = ","
+	defaultSliceSeparator  = ","
+	defaultNestedSeparator = "."
 )
 
 type DecodeStruct struct {
 	cols       []*csvFieldInfo
 	foundCols  []*csvFieldInfo
 	recordType reflect.Type
+	headerLen  int
+	rowCount   int
 
-	timeFormat     string
-	csvTagName     string
-	csvTagFieldSep string
+	config
 }
 
 type csvFieldInfo struct {
 	Header           string
 	Required         bool
+	SliceSep         string
 	RecordIndex      int
-	StructFieldIndex int
+	StructFieldIndex []int
 }
 
-type option func(c *DecodeStruct)
+// config holds the settings shared by DecodeStruct and EncodeStruct.
+type config struct {
+	timeFormat          string
+	csvTagName          string
+	csvTagFieldSep      string
+	sliceSeparator      string
+	nestedSeparator     string
+	typeDecoders        map[reflect.Type]TypeDecoderFunc
+	errorPolicy         ErrorPolicy
+	allowUnknownColumns bool
+}
+
+func newConfig() config {
+	return config{
+		timeFormat:      time.RFC3339,
+		csvTagName:      defaultCSVTagName,
+		csvTagFieldSep:  defaultCSVTagFieldSep,
+		sliceSeparator:  defaultSliceSeparator,
+		nestedSeparator: defaultNestedSeparator,
+	}
+}
+
+// parseFieldTag parses the csv tag of a struct field into its header name and
+// options. skip reports whether the field was tagged with "-" and should be
+// ignored entirely.
+func parseFieldTag(csvTag, tagFieldSep, fieldName string) (header string, required bool, sliceSep string, skip bool) {
+	if csvTag == "-" {
+		return "", false, "", true
+	}
+	header = fieldName
+	if len(csvTag) == 0 {
+		return header, false, "", false
+	}
+	tagFields := strings.Split(csvTag, tagFieldSep)
+	header = tagFields[0]
+	for _, tf := range tagFields[1:] {
+		switch {
+		case tf == "required":
+			required = true
+		case strings.HasPrefix(tf, "sep="):
+			sliceSep = strings.TrimPrefix(tf, "sep=")
+		}
+	}
+	return header, required, sliceSep, false
+}
+
+type option func(c *config)
 
 // WithTimeFormat option to change time format
 func WithTimeFormat(s string) option {
-	return func(c *DecodeStruct) {
+	return func(c *config) {
 		c.timeFormat = s
 	}
 }
 
 // WithCSVTagName option to change csv tag name
 func WithCSVTagName(n string) option {
-	return func(c *DecodeStruct) {
+	return func(c *config) {
 		c.csvTagName = n
 	}
 }
 
 // WithCSVTagFieldSep option to change csv tag field separator
 func WithCSVTagFieldSep(s string) option {
-	return func(c *DecodeStruct) {
+	return func(c *config) {
 		c.csvTagFieldSep = s
 	}
 }
 
+// WithSliceSeparator option to change the default separator used to split a
+// CSV cell into a slice field's elements. It can be overridden per field with
+// a `sep=` tag option, e.g. `csv:"tags,,sep=|"`.
+func WithSliceSeparator(s string) option {
+	return func(c *config) {
+		c.sliceSeparator = s
+	}
+}
+
+// WithNestedSeparator option to change the separator used to join a nested
+// struct field's tag/name with its own fields' headers, e.g. "addr.city".
+func WithNestedSeparator(s string) option {
+	return func(c *config) {
+		c.nestedSeparator = s
+	}
+}
+
+// buildCSVFields walks rt's fields, recursing into nested structs (embedded
+// or named) to produce a flat list of leaf csvFieldInfo, with headers
+// composed as parentHeader + nestedSeparator + fieldHeader. kind names the
+// caller ("reader" or "writer") for panic messages.
+func buildCSVFields(c *config, rt reflect.Type, kind string) []*csvFieldInfo {
+	return buildCSVFieldsRec(c, rt, nil, "", kind)
+}
+
+func buildCSVFieldsRec(c *config, rt reflect.Type, indexPrefix []int, headerPrefix string, kind string) []*csvFieldInfo {
+	var cols []*csvFieldInfo
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		csvTag := f.Tag.Get(c.csvTagName)
+		headerName, required, sliceSep, skip := parseFieldTag(csvTag, c.csvTagFieldSep, f.Name)
+		if skip {
+			continue
+		}
+		index := make([]int, len(indexPrefix)+1)
+		copy(index, indexPrefix)
+		index[len(indexPrefix)] = i
+		lowerName := strings.ToLower(headerName)
+
+		if isNestedStruct(c, f.Type) {
+			nestedPrefix := headerPrefix
+			if !f.Anonymous {
+				nestedPrefix = joinHeader(headerPrefix, lowerName, c.nestedSeparator)
+			}
+			nestedCols := buildCSVFieldsRec(c, f.Type, index, nestedPrefix, kind)
+			if required {
+				for _, nc := range nestedCols {
+					nc.Required = true
+				}
+			}
+			cols = append(cols, nestedCols...)
+			continue
+		}
+		if !c.isSupportedField(f.Type) {
+			panic(fmt.Sprintf("CSV struct %s does not support struct field with type: %s", kind, f.Type.String()))
+		}
+		cols = append(cols, &csvFieldInfo{
+			Header:           joinHeader(headerPrefix, lowerName, c.nestedSeparator),
+			Required:         required,
+			SliceSep:         sliceSep,
+			StructFieldIndex: index,
+			RecordIndex:      -1, // special flag to indicate un-initialized status
+		})
+	}
+	return cols
+}
+
+// isNestedStruct reports whether t should be flattened into its own fields
+// rather than decoded/encoded as a single value.
+func isNestedStruct(c *config, t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.String() != "time.Time" && !c.hasCustomDecoder(t)
+}
+
+func joinHeader(prefix, name, sep string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + sep + name
+}
+
 // NewDecodeStruct return pointer to DecodeStruct with given struct and options
 func NewDecodeStruct(v interface{}, opts ...option) *DecodeStruct {
 	rv := reflect.ValueOf(v)
@@ -63,48 +192,12 @@ func NewDecodeStruct(v interface{}, opts ...option) *DecodeStruct {
 	if rv.Kind() != reflect.Struct {
 		panic("argument of NewDecodeStruct must be an struct or pointer to struct")
 	}
-	var (
-		headerName, csvTag string
-		required           bool
-	)
-	reader := &DecodeStruct{
-		timeFormat:     time.RFC3339,
-		csvTagName:     defaultCSVTagName,
-		csvTagFieldSep: defaultCSVTagFieldSep,
-	}
+	reader := &DecodeStruct{config: newConfig()}
 	for _, opt := range opts {
-		opt(reader)
+		opt(&reader.config)
 	}
 	rt := rv.Type()
-	for i := 0; i < rt.NumField(); i++ {
-		f := rt.Field(i)
-		if !isSupportedField(f.Type) {
-			panic(fmt.Sprintf("CSV struct reader does not support struct field with type: %s", f.Type.String()))
-		}
-		headerName = f.Name
-		required = false
-		csvTag = f.Tag.Get(reader.csvTagName)
-		// ignore the field if tag is "-"
-		if csvTag == "-" {
-			continue
-		}
-		if len(csvTag) > 0 {
-			tagFields := strings.Split(csvTag, reader.csvTagFieldSep)
-			headerName = tagFields[0]
-			if len(tagFields) > 1 && tagFields[1] == "required" {
-				required = true
-			}
-		}
-		reader.cols = append(
-			reader.cols,
-			&csvFieldInfo{
-				Header:           strings.ToLower(headerName),
-				Required:         required,
-				StructFieldIndex: i,
-				RecordIndex:      -1, // special flag to indicate un-initialized status
-			},
-		)
-	}
+	reader.cols = buildCSVFields(&reader.config, rt, "reader")
 	reader.recordType = rt
 	return reader
 }
@@ -123,7 +216,7 @@ func (r *DecodeStruct) ParseHeader(header []string) error {
 				r.foundCols = append(r.foundCols, f)
 			}
 		}
-		if !found {
+		if !found && !r.allowUnknownColumns {
 			return NewIncorrectFileErr(fmt.Sprintf("Unexpected column %q", header[i]))
 		}
 	}
@@ -133,22 +226,26 @@ func (r *DecodeStruct) ParseHeader(header []string) error {
 			return NewIncorrectFileErr(fmt.Sprintf("Mandatory column %q is missing", f.Header))
 		}
 	}
+	r.headerLen = len(header)
 	return nil
 }
 
 // GetStruct create new struct pointer then unmarshal record to that struct
+// Under PolicyCollect the returned value is still populated with whatever
+// fields decoded successfully, alongside the aggregated *MultiError.
 func (r *DecodeStruct) GetStruct(record []string) (v interface{}, err error) {
 	v = reflect.New(r.recordType).Interface()
-	if err = r.UnmarshalCSV(record, v); err != nil {
+	err = r.UnmarshalCSV(record, v)
+	if err != nil && r.errorPolicy != PolicyCollect {
 		return nil, err
 	}
-	return v, nil
+	return v, err
 }
 
 // UnmarshalCSV convert csv row to container v
 // v must be pointer to struct that have same type with struct in constructor function
 func (r *DecodeStruct) UnmarshalCSV(record []string, v interface{}) (err error) {
-	if len(record) != len(r.foundCols) {
+	if len(record) != r.headerLen {
 		return errors.New("csv record must have same column with csv header")
 	}
 	rv := reflect.ValueOf(v).Elem()
@@ -165,32 +262,55 @@ func (r *DecodeStruct) reset() *DecodeStruct {
 		col.RecordIndex = -1
 	}
 	r.foundCols = nil
+	r.rowCount = 0
 	return r
 }
 
 func (r *DecodeStruct) unmarshal(rv reflect.Value, record []string) error {
+	r.rowCount++
+	var multi MultiError
 	for _, c := range r.foundCols {
 		s := record[c.RecordIndex]
 		if !c.Required && len(s) == 0 {
 			continue // ignore empty value for optional column
 		}
-		f := rv.Field(c.StructFieldIndex)
-		if f.CanSet() {
-			if err := r.setField(f, s); err != nil {
-				return err
+		f := rv.FieldByIndex(c.StructFieldIndex)
+		if !f.CanSet() {
+			continue
+		}
+		sep := c.SliceSep
+		if sep == "" {
+			sep = r.sliceSeparator
+		}
+		if err := r.setField(f, s, sep); err != nil {
+			fe := &FieldError{Row: r.rowCount, Column: c.RecordIndex, Header: c.Header, Value: s, Err: err}
+			if r.errorPolicy != PolicyCollect {
+				return fe
 			}
+			multi.Errors = append(multi.Errors, fe)
 		}
 	}
+	if len(multi.Errors) > 0 {
+		return &multi
+	}
 	return nil
 }
 
-func (r *DecodeStruct) setField(f reflect.Value, s string) error {
-	switch f.Kind() {
-	case reflect.Ptr:
+// setField decodes s into f. sep is the slice separator to use if f (or an
+// element of f, when f is a slice) turns out to need one.
+func (r *DecodeStruct) setField(f reflect.Value, s string, sep string) error {
+	if f.Kind() == reflect.Ptr {
 		z := reflect.New(f.Type().Elem())
 		f.Set(z)
 		f = reflect.Indirect(f)
-		return r.setField(f, s)
+		return r.setField(f, s, sep)
+	}
+	if handled, err := r.decodeCustom(f, s); handled {
+		return err
+	}
+	switch f.Kind() {
+	case reflect.Slice:
+		return r.setSliceField(f, s, sep)
 	case reflect.String:
 		f.SetString(s)
 		return nil
@@ -238,8 +358,29 @@ func (r *DecodeStruct) setField(f reflect.Value, s string) error {
 	}
 }
 
+// setSliceField splits s on sep and decodes each part into a new element of
+// f using setField, so any type setField supports can also appear as a slice.
+func (r *DecodeStruct) setSliceField(f reflect.Value, s string, sep string) error {
+	if len(s) == 0 {
+		f.Set(reflect.MakeSlice(f.Type(), 0, 0))
+		return nil
+	}
+	parts := strings.Split(s, sep)
+	slice := reflect.MakeSlice(f.Type(), len(parts), len(parts))
+	for i, p := range parts {
+		if err := r.setField(slice.Index(i), strings.TrimSpace(p), sep); err != nil {
+			return err
+		}
+	}
+	f.Set(slice)
+	return nil
+}
+
 // isSupportedField
-func isSupportedField(f reflect.Type) bool {
+func (c *config) isSupportedField(f reflect.Type) bool {
+	if c.hasCustomDecoder(f) {
+		return true
+	}
 	switch f.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
@@ -250,8 +391,10 @@ func isSupportedField(f reflect.Type) bool {
 			return true
 		}
 		return false
+	case reflect.Slice:
+		return c.isSupportedField(f.Elem())
 	case reflect.Ptr:
-		return isSupportedField(f.Elem())
+		return c.isSupportedField(f.Elem())
 	default:
 		return false
 	}
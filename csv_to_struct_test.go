@@ -37,13 +37,13 @@ func TestNewDecodeStruct(t *testing.T) {
 		var _ = csv2struct.NewDecodeStruct(
 			struct {
 				FieldA struct {
-					FieldB string
+					FieldB map[string]string
 				}
 			}{},
 		)
 	})
 	if err == nil {
-		t.Errorf("using struct data type for struct field must cause panic")
+		t.Errorf("using unsupported data type inside a nested struct field must cause panic")
 	}
 }
 
@@ -0,0 +1,142 @@
+package csv2struct
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeStruct marshals values of a single struct type into CSV rows. It is
+// the symmetric counterpart of DecodeStruct and shares its tag parsing rules.
+type EncodeStruct struct {
+	cols       []*csvFieldInfo
+	recordType reflect.Type
+
+	config
+}
+
+// NewEncodeStruct return pointer to EncodeStruct with given struct and options
+func NewEncodeStruct(v interface{}, opts ...option) *EncodeStruct {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic("argument of NewEncodeStruct must be an struct or pointer to struct")
+	}
+	encoder := &EncodeStruct{config: newConfig()}
+	for _, opt := range opts {
+		opt(&encoder.config)
+	}
+	rt := rv.Type()
+	encoder.cols = buildCSVFields(&encoder.config, rt, "writer")
+	encoder.recordType = rt
+	return encoder
+}
+
+// Header returns the CSV column names in the order MarshalCSV emits them.
+func (e *EncodeStruct) Header() []string {
+	header := make([]string, len(e.cols))
+	for i, c := range e.cols {
+		header[i] = c.Header
+	}
+	return header
+}
+
+// MarshalCSV convert v to a csv row
+// v must be a struct or pointer to struct that have same type with struct in constructor function
+func (e *EncodeStruct) MarshalCSV(v interface{}) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Type() != e.recordType {
+		return nil, fmt.Errorf("argument of MarshalCSV function must be a %s or pointer to it", e.recordType.String())
+	}
+	record := make([]string, len(e.cols))
+	for i, c := range e.cols {
+		sep := c.SliceSep
+		if sep == "" {
+			sep = e.sliceSeparator
+		}
+		s, err := e.formatField(rv.FieldByIndex(c.StructFieldIndex), sep)
+		if err != nil {
+			return nil, err
+		}
+		record[i] = s
+	}
+	return record, nil
+}
+
+// Marshal writes the header followed by one CSV row per element of slice to w.
+// slice must be a slice of the struct type given to NewEncodeStruct, or of
+// pointers to it.
+func (e *EncodeStruct) Marshal(w io.Writer, slice interface{}) error {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice {
+		return errors.New("argument of Marshal function must be a slice")
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(e.Header()); err != nil {
+		return err
+	}
+	for i := 0; i < rv.Len(); i++ {
+		record, err := e.MarshalCSV(rv.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (e *EncodeStruct) formatField(f reflect.Value, sep string) (string, error) {
+	if f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			return "", nil
+		}
+		f = f.Elem()
+	}
+	switch f.Kind() {
+	case reflect.String:
+		return f.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(f.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(f.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(f.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(f.Float(), 'f', -1, 64), nil
+	case reflect.Struct:
+		// support struct for only time.Time
+		if s := f.Type().String(); s != "time.Time" {
+			return "", fmt.Errorf("CSV struct writer does not support struct field with type: %s", s)
+		}
+		t := f.Interface().(time.Time)
+		return t.Format(e.timeFormat), nil
+	case reflect.Slice:
+		parts := make([]string, f.Len())
+		for i := 0; i < f.Len(); i++ {
+			s, err := e.formatField(f.Index(i), sep)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, sep), nil
+	default:
+		return "", fmt.Errorf("CSV struct writer does not support struct field with type: %s", f.Type().String())
+	}
+}
@@ -0,0 +1,118 @@
+package csv2struct_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/htmd/csv2struct"
+)
+
+func TestNewEncodeStruct(t *testing.T) {
+	s := struct {
+		FieldA map[string]string
+	}{}
+
+	err := runCatchPanic(func() {
+		var _ = csv2struct.NewEncodeStruct(s)
+	})
+	if err == nil {
+		t.Errorf("using map data type for struct field must cause panic")
+	}
+}
+
+func TestEncodeStruct_Header(t *testing.T) {
+	encoder := csv2struct.NewEncodeStruct(&Record{})
+	expect := []string{
+		"string field",
+		"integer field",
+		"unsigned integer field",
+		"boolean field",
+		"float field",
+		"optional time field",
+		"optionalintfield",
+		"optionalintpointer",
+	}
+	if !reflect.DeepEqual(encoder.Header(), expect) {
+		t.Errorf("expecting header: %+v \nbut got: %+v", expect, encoder.Header())
+	}
+}
+
+func TestEncodeStruct_MarshalCSV(t *testing.T) {
+	encoder := csv2struct.NewEncodeStruct(&Record{})
+	now := time.Date(2017, 10, 9, 12, 30, 30, 0, time.Local)
+	pInt := -10
+	rec := &Record{
+		StringField:        "field 1",
+		IntField:           -30,
+		UintField:          100,
+		BoolField:          true,
+		FloatField:         -200.0,
+		OptionalTimeField:  now,
+		OptionalIntField:   50,
+		OptionalIntPointer: &pInt,
+	}
+
+	got, err := encoder.MarshalCSV(rec)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	expect := []string{
+		"field 1",
+		"-30",
+		"100",
+		"true",
+		"-200",
+		now.Format(time.RFC3339),
+		"50",
+		"-10",
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expecting: %+v \nbut got: %+v", expect, got)
+	}
+
+	rec.OptionalIntPointer = nil
+	got, err = encoder.MarshalCSV(rec)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if got[7] != "" {
+		t.Errorf("expecting empty string for nil pointer field but got: %q", got[7])
+	}
+
+	_, err = encoder.MarshalCSV(&struct{ Field1 int }{})
+	if err == nil {
+		t.Errorf("expecting error when argument type does not match the encoder's struct type")
+	}
+}
+
+func TestEncodeStruct_Marshal(t *testing.T) {
+	encoder := csv2struct.NewEncodeStruct(&Record{})
+	now := time.Date(2017, 10, 9, 12, 30, 30, 0, time.Local)
+	records := []*Record{
+		{
+			StringField: "field 1",
+			IntField:    -30,
+			UintField:   100,
+			BoolField:   true,
+			FloatField:  -200.0,
+		},
+		{
+			StringField:       "field 2",
+			IntField:          30,
+			UintField:         200,
+			BoolField:         false,
+			FloatField:        200.0,
+			OptionalTimeField: now,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Marshal(&buf, records); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if buf.Len() == 0 {
+		t.Errorf("expecting non-empty CSV output")
+	}
+}
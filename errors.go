@@ -1,5 +1,10 @@
 package csv2struct
 
+import (
+	"fmt"
+	"strings"
+)
+
 type IncorrectFileErr struct {
 	message string
 }
@@ -13,3 +18,46 @@ func NewIncorrectFileErr(message string) IncorrectFileErr {
 func (e IncorrectFileErr) Error() string {
 	return e.message
 }
+
+// FieldError reports a single cell that failed to decode, with enough
+// context (row/column/header) to locate it in the source CSV.
+type FieldError struct {
+	Row    int
+	Column int
+	Header string
+	Value  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("row %d, column %d (%q): %s", e.Row, e.Column, e.Header, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError aggregates the FieldErrors collected while decoding under
+// PolicyCollect.
+type MultiError struct {
+	Errors []*FieldError
+}
+
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add merges err into the aggregate, flattening a nested *MultiError. Errors
+// that are neither a *FieldError nor a *MultiError are ignored.
+func (e *MultiError) Add(err error) {
+	switch v := err.(type) {
+	case *FieldError:
+		e.Errors = append(e.Errors, v)
+	case *MultiError:
+		e.Errors = append(e.Errors, v.Errors...)
+	}
+}
@@ -0,0 +1,90 @@
+package csv2struct_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/htmd/csv2struct"
+)
+
+type Address struct {
+	City string `csv:"city"`
+	Zip  string `csv:"zip"`
+}
+
+type Contact struct {
+	Name string `csv:"name,required"`
+	Address
+	Billing Address `csv:"billing,required"`
+}
+
+func TestNewDecodeStruct_Nested(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&Contact{})
+	header := []string{"name", "city", "zip", "billing.city", "billing.zip"}
+	if err := decoder.ParseHeader(header); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := decoder.GetStruct([]string{"Jane", "Springfield", "11111", "Shelbyville", "22222"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, ok := v.(*Contact)
+	if !ok {
+		t.Fatalf("unexpected struct type: %T", v)
+	}
+	expect := &Contact{
+		Name:    "Jane",
+		Address: Address{City: "Springfield", Zip: "11111"},
+		Billing: Address{City: "Shelbyville", Zip: "22222"},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expecting: %+v \nbut got: %+v", expect, got)
+	}
+}
+
+func TestNewDecodeStruct_NestedRequired(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&Contact{})
+	header := []string{"name", "city", "zip"}
+	err := decoder.ParseHeader(header)
+	if err == nil {
+		t.Fatalf("expecting error when required nested struct columns are missing from header")
+	}
+}
+
+func TestNewDecodeStruct_NestedSeparator(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&Contact{}, csv2struct.WithNestedSeparator("_"))
+	header := []string{"name", "city", "zip", "billing_city", "billing_zip"}
+	if err := decoder.ParseHeader(header); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestEncodeStruct_Nested(t *testing.T) {
+	encoder := csv2struct.NewEncodeStruct(&Contact{})
+	header := encoder.Header()
+	expectHeader := []string{"name", "city", "zip", "billing.city", "billing.zip"}
+	if !reflect.DeepEqual(header, expectHeader) {
+		t.Errorf("expecting header: %v \nbut got: %v", expectHeader, header)
+	}
+
+	contact := &Contact{
+		Name:    "Jane",
+		Address: Address{City: "Springfield", Zip: "11111"},
+		Billing: Address{City: "Shelbyville", Zip: "22222"},
+	}
+	record, err := encoder.MarshalCSV(contact)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectRecord := []string{"Jane", "Springfield", "11111", "Shelbyville", "22222"}
+	if !reflect.DeepEqual(record, expectRecord) {
+		t.Errorf("expecting record: %v \nbut got: %v", expectRecord, record)
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Marshal(&buf, []*Contact{contact}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
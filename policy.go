@@ -0,0 +1,44 @@
+package csv2struct
+
+// ErrorPolicy controls how DecodeStruct and Reader react to a cell that
+// fails to decode.
+type ErrorPolicy int
+
+const (
+	// PolicyFailFast aborts on the first field error (the default).
+	PolicyFailFast ErrorPolicy = iota
+	// PolicyCollect keeps decoding past field errors and aggregates them
+	// into a *MultiError, alongside whatever was successfully decoded.
+	PolicyCollect
+	// PolicySkipRow drops a row as soon as one of its fields fails to
+	// decode and moves on to the next row. Only affects the streaming
+	// Reader; UnmarshalCSV/GetStruct have no further row to move on to.
+	PolicySkipRow
+)
+
+// WithErrorPolicy option to change how field decode errors are handled
+func WithErrorPolicy(p ErrorPolicy) option {
+	return func(c *config) {
+		c.errorPolicy = p
+	}
+}
+
+// WithAllowUnknownColumns option to ignore header columns that don't map to
+// any struct field instead of failing ParseHeader
+func WithAllowUnknownColumns(allow bool) option {
+	return func(c *config) {
+		c.allowUnknownColumns = allow
+	}
+}
+
+// isFieldErr reports whether err carries FieldError context, i.e. is a
+// *FieldError or *MultiError rather than a structural error like a row/header
+// column-count mismatch.
+func isFieldErr(err error) bool {
+	switch err.(type) {
+	case *FieldError, *MultiError:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,106 @@
+package csv2struct_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/htmd/csv2struct"
+)
+
+func TestDecodeStruct_ErrorPolicyFailFast(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&Record{})
+	header := []string{"String Field", "Integer Field", "Unsigned Integer Field", "Boolean Field", "Float Field"}
+	if err := decoder.ParseHeader(header); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := decoder.GetStruct([]string{"field 1", "not-an-int", "100", "true", "-200"})
+	if v != nil {
+		t.Errorf("expecting nil value on PolicyFailFast error but got: %+v", v)
+	}
+	fe, ok := err.(*csv2struct.FieldError)
+	if !ok {
+		t.Fatalf("expecting *FieldError but got: %T", err)
+	}
+	if fe.Header != "integer field" {
+		t.Errorf("expecting error on column %q but got %q", "integer field", fe.Header)
+	}
+}
+
+func TestDecodeStruct_ErrorPolicyCollect(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&Record{}, csv2struct.WithErrorPolicy(csv2struct.PolicyCollect))
+	header := []string{"String Field", "Integer Field", "Unsigned Integer Field", "Boolean Field", "Float Field"}
+	if err := decoder.ParseHeader(header); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := decoder.GetStruct([]string{"field 1", "not-an-int", "not-a-uint", "true", "-200"})
+	multi, ok := err.(*csv2struct.MultiError)
+	if !ok {
+		t.Fatalf("expecting *MultiError but got: %T", err)
+	}
+	if len(multi.Errors) != 2 {
+		t.Errorf("expecting 2 collected errors but got %d", len(multi.Errors))
+	}
+	rec, ok := v.(*Record)
+	if !ok {
+		t.Fatalf("expecting partially decoded *Record but got: %T", v)
+	}
+	if rec.StringField != "field 1" || !rec.BoolField {
+		t.Errorf("expecting successfully decoded fields to still be set, got: %+v", rec)
+	}
+}
+
+func TestParseHeader_AllowUnknownColumns(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&Record{}, csv2struct.WithAllowUnknownColumns(true))
+	header := []string{"String Field", "Integer Field", "Unsigned Integer Field", "Boolean Field", "Float Field", "Some Unexpected Column"}
+	if err := decoder.ParseHeader(header); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := decoder.GetStruct([]string{"field 1", "-30", "100", "true", "-200", "ignored"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rec := v.(*Record)
+	if rec.StringField != "field 1" {
+		t.Errorf("unexpected decoded record: %+v", rec)
+	}
+}
+
+const policyCSV = `String Field,Integer Field,Unsigned Integer Field,Boolean Field,Float Field
+field 1,-30,100,true,-200
+field 2,not-an-int,200,false,200
+field 3,30,300,true,300
+`
+
+func TestReader_PolicySkipRow(t *testing.T) {
+	r := csv2struct.NewReader(strings.NewReader(policyCSV), &Record{}, csv2struct.WithErrorPolicy(csv2struct.PolicySkipRow))
+
+	all, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expecting 2 surviving records but got %d", len(all))
+	}
+	if all[0].(*Record).StringField != "field 1" || all[1].(*Record).StringField != "field 3" {
+		t.Errorf("unexpected surviving records: %+v", all)
+	}
+}
+
+func TestReader_PolicyCollect(t *testing.T) {
+	r := csv2struct.NewReader(strings.NewReader(policyCSV), &Record{}, csv2struct.WithErrorPolicy(csv2struct.PolicyCollect))
+
+	all, err := r.ReadAll()
+	multi, ok := err.(*csv2struct.MultiError)
+	if !ok {
+		t.Fatalf("expecting *MultiError but got: %T", err)
+	}
+	if len(multi.Errors) != 1 {
+		t.Errorf("expecting 1 collected error but got %d", len(multi.Errors))
+	}
+	if len(all) != 3 {
+		t.Errorf("expecting all 3 rows (including the partially decoded one) but got %d", len(all))
+	}
+}
@@ -0,0 +1,149 @@
+package csv2struct
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// CSVReader is the minimal set of methods Reader needs to pull raw rows from.
+// *encoding/csv.Reader satisfies it.
+type CSVReader interface {
+	Read() ([]string, error)
+	ReadAll() ([][]string, error)
+}
+
+// Reader streams CSV rows into values of the struct type given to NewReader,
+// wrapping DecodeStruct so callers don't have to drive ParseHeader/GetStruct
+// by hand.
+type Reader struct {
+	cr         CSVReader
+	decoder    *DecodeStruct
+	headerRead bool
+}
+
+// NewReader returns a Reader that decodes CSV read from r into values of the
+// same type as proto (a struct or pointer to struct).
+func NewReader(r io.Reader, proto interface{}, opts ...option) *Reader {
+	return NewReaderFromCSV(csv.NewReader(r), proto, opts...)
+}
+
+// NewReaderFromCSV returns a Reader that pulls rows from cr instead of an
+// encoding/csv.Reader constructed internally, so callers can configure
+// quoting, delimiters, etc. on cr before handing it over.
+func NewReaderFromCSV(cr CSVReader, proto interface{}, opts ...option) *Reader {
+	return &Reader{
+		cr:      cr,
+		decoder: NewDecodeStruct(proto, opts...),
+	}
+}
+
+// Read returns the next decoded row, or io.EOF once the source is exhausted.
+// Under PolicySkipRow a row whose fields fail to decode is silently dropped
+// in favor of the next one.
+func (r *Reader) Read() (interface{}, error) {
+	if err := r.ensureHeader(); err != nil {
+		return nil, err
+	}
+	for {
+		record, err := r.cr.Read()
+		if err != nil {
+			return nil, err
+		}
+		v, err := r.decoder.GetStruct(record)
+		if err != nil {
+			if r.decoder.errorPolicy == PolicySkipRow && isFieldErr(err) {
+				continue
+			}
+			return v, err
+		}
+		return v, nil
+	}
+}
+
+func (r *Reader) ensureHeader() error {
+	if r.headerRead {
+		return nil
+	}
+	header, err := r.cr.Read()
+	if err != nil {
+		return err
+	}
+	if err := r.decoder.ParseHeader(header); err != nil {
+		return err
+	}
+	r.headerRead = true
+	return nil
+}
+
+// ReadAll reads the remaining rows until io.EOF and returns them all. Under
+// PolicyCollect it keeps reading past field errors, returning every row that
+// decoded (even partially) together with the aggregated *MultiError.
+func (r *Reader) ReadAll() ([]interface{}, error) {
+	var out []interface{}
+	var multi MultiError
+	for {
+		v, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if r.decoder.errorPolicy == PolicyCollect && isFieldErr(err) {
+				multi.Add(err)
+				if v != nil {
+					out = append(out, v)
+				}
+				continue
+			}
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if len(multi.Errors) > 0 {
+		return out, &multi
+	}
+	return out, nil
+}
+
+// Decode reads the remaining rows and appends them to out, which must be a
+// pointer to a slice of the struct type (or pointer to that struct type)
+// given to NewReader. It shares ReadAll's PolicyCollect behavior.
+func (r *Reader) Decode(out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("argument of Decode function must be a pointer to a slice")
+	}
+	slice := rv.Elem()
+	elemIsPtr := slice.Type().Elem().Kind() == reflect.Ptr
+	var multi MultiError
+	for {
+		v, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if r.decoder.errorPolicy == PolicyCollect && isFieldErr(err) {
+				multi.Add(err)
+				if v != nil {
+					appendDecoded(slice, v, elemIsPtr)
+				}
+				continue
+			}
+			return err
+		}
+		appendDecoded(slice, v, elemIsPtr)
+	}
+	if len(multi.Errors) > 0 {
+		return &multi
+	}
+	return nil
+}
+
+func appendDecoded(slice reflect.Value, v interface{}, elemIsPtr bool) {
+	ev := reflect.ValueOf(v)
+	if !elemIsPtr {
+		ev = ev.Elem()
+	}
+	slice.Set(reflect.Append(slice, ev))
+}
@@ -0,0 +1,71 @@
+package csv2struct_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/htmd/csv2struct"
+)
+
+const readerCSV = `String Field,Integer Field,Unsigned Integer Field,Boolean Field,Float Field
+field 1,-30,100,true,-200
+field 2,30,200,false,200
+`
+
+func TestReader_Read(t *testing.T) {
+	r := csv2struct.NewReader(strings.NewReader(readerCSV), &Record{})
+
+	v, err := r.Read()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rec, ok := v.(*Record)
+	if !ok {
+		t.Fatalf("unexpected type: %T", v)
+	}
+	if rec.StringField != "field 1" {
+		t.Errorf("expecting %q but got %q", "field 1", rec.StringField)
+	}
+
+	if _, err = r.Read(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err = r.Read(); err != io.EOF {
+		t.Errorf("expecting io.EOF but got: %v", err)
+	}
+}
+
+func TestReader_ReadAll(t *testing.T) {
+	r := csv2struct.NewReader(strings.NewReader(readerCSV), &Record{})
+
+	all, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expecting 2 records but got %d", len(all))
+	}
+}
+
+func TestReader_Decode(t *testing.T) {
+	r := csv2struct.NewReader(strings.NewReader(readerCSV), &Record{})
+
+	var out []*Record
+	if err := r.Decode(&out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expecting 2 records but got %d", len(out))
+	}
+	if out[0].StringField != "field 1" || out[1].StringField != "field 2" {
+		t.Errorf("unexpected decoded records: %+v", out)
+	}
+
+	r2 := csv2struct.NewReader(strings.NewReader(readerCSV), &Record{})
+	var notASlice Record
+	if err := r2.Decode(&notASlice); err == nil {
+		t.Errorf("expecting error when decoding into a non-pointer-to-slice")
+	}
+}
@@ -0,0 +1,114 @@
+package csv2struct_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/htmd/csv2struct"
+)
+
+type sliceRecord struct {
+	Tags   []string `csv:"tags,,sep=|"`
+	Scores []int    `csv:"scores"`
+}
+
+func TestDecodeStruct_SliceField(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&sliceRecord{})
+	if err := decoder.ParseHeader([]string{"tags", "scores"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := decoder.GetStruct([]string{"red|blue|green", "1,2,3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := v.(*sliceRecord)
+	expect := &sliceRecord{
+		Tags:   []string{"red", "blue", "green"},
+		Scores: []int{1, 2, 3},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expecting: %+v \nbut got: %+v", expect, got)
+	}
+}
+
+func TestDecodeStruct_SliceFieldGlobalSeparator(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&sliceRecord{}, csv2struct.WithSliceSeparator("|"))
+	if err := decoder.ParseHeader([]string{"tags", "scores"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := decoder.GetStruct([]string{"red|blue", "4|5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := v.(*sliceRecord)
+	expect := &sliceRecord{
+		Tags:   []string{"red", "blue"},
+		Scores: []int{4, 5},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expecting: %+v \nbut got: %+v", expect, got)
+	}
+}
+
+type sliceOfCustomRecord struct {
+	Points []point `csv:"points,,sep=;"`
+}
+
+func TestDecodeStruct_SliceOfCustomUnmarshaler(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&sliceOfCustomRecord{})
+	if err := decoder.ParseHeader([]string{"points"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v, err := decoder.GetStruct([]string{"1:2;3:4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := v.(*sliceOfCustomRecord)
+	expect := &sliceOfCustomRecord{Points: []point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expecting: %+v \nbut got: %+v", expect, got)
+	}
+}
+
+type sliceOfTimeRecord struct {
+	Dates []time.Time `csv:"dates,,sep=;"`
+}
+
+func TestDecodeStruct_SliceOfTime(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&sliceOfTimeRecord{})
+	if err := decoder.ParseHeader([]string{"dates"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	v, err := decoder.GetStruct([]string{d1.Format(time.RFC3339) + ";" + d2.Format(time.RFC3339)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := v.(*sliceOfTimeRecord)
+	if !got.Dates[0].Equal(d1) || !got.Dates[1].Equal(d2) {
+		t.Errorf("unexpected dates: %+v", got.Dates)
+	}
+}
+
+func TestEncodeStruct_SliceField(t *testing.T) {
+	encoder := csv2struct.NewEncodeStruct(&sliceRecord{})
+	rec := &sliceRecord{
+		Tags:   []string{"red", "blue", "green"},
+		Scores: []int{1, 2, 3},
+	}
+
+	got, err := encoder.MarshalCSV(rec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expect := []string{"red|blue|green", "1,2,3"}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expecting: %+v \nbut got: %+v", expect, got)
+	}
+}
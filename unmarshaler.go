@@ -0,0 +1,70 @@
+package csv2struct
+
+import (
+	"encoding"
+	"reflect"
+	"time"
+)
+
+// CSVUnmarshaler is implemented by types that know how to parse themselves
+// from a single CSV cell. setField checks for it (and for
+// encoding.TextUnmarshaler) before falling back to its built-in type switch.
+type CSVUnmarshaler interface {
+	UnmarshalCSV(data []byte) error
+}
+
+// TypeDecoderFunc parses s into v, a settable reflect.Value of the registered type.
+type TypeDecoderFunc func(s string, v reflect.Value) error
+
+var (
+	csvUnmarshalerType  = reflect.TypeOf((*CSVUnmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// WithTypeDecoder registers fn to decode CSV cells into fields of type t,
+// for types the caller does not own and so cannot implement CSVUnmarshaler
+// on directly (e.g. uuid.UUID, decimal.Decimal).
+func WithTypeDecoder(t reflect.Type, fn TypeDecoderFunc) option {
+	return func(c *config) {
+		if c.typeDecoders == nil {
+			c.typeDecoders = make(map[reflect.Type]TypeDecoderFunc)
+		}
+		c.typeDecoders[t] = fn
+	}
+}
+
+// hasCustomDecoder reports whether t can be decoded via a registered
+// TypeDecoderFunc or because it implements CSVUnmarshaler/TextUnmarshaler.
+func (c *config) hasCustomDecoder(t reflect.Type) bool {
+	if _, ok := c.typeDecoders[t]; ok {
+		return true
+	}
+	pt := reflect.PtrTo(t)
+	return pt.Implements(csvUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// decodeCustom tries to decode s into f using a registered TypeDecoderFunc or
+// the CSVUnmarshaler/TextUnmarshaler interfaces. It reports whether f was
+// handled at all.
+func (c *config) decodeCustom(f reflect.Value, s string) (bool, error) {
+	if fn, ok := c.typeDecoders[f.Type()]; ok {
+		return true, fn(s, f)
+	}
+	// time.Time implements encoding.TextUnmarshaler, but is left to setField
+	// so that it is parsed with the configured time format.
+	if f.Type() == timeType {
+		return false, nil
+	}
+	if !f.CanAddr() {
+		return false, nil
+	}
+	addr := f.Addr()
+	if u, ok := addr.Interface().(CSVUnmarshaler); ok {
+		return true, u.UnmarshalCSV([]byte(s))
+	}
+	if u, ok := addr.Interface().(encoding.TextUnmarshaler); ok {
+		return true, u.UnmarshalText([]byte(s))
+	}
+	return false, nil
+}
@@ -0,0 +1,93 @@
+package csv2struct_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/htmd/csv2struct"
+)
+
+// upperString implements csv2struct.CSVUnmarshaler via a pointer receiver.
+type upperString string
+
+func (u *upperString) UnmarshalCSV(data []byte) error {
+	*u = upperString(strings.ToUpper(string(data)))
+	return nil
+}
+
+type point struct {
+	X, Y int
+}
+
+func (p *point) UnmarshalText(data []byte) error {
+	parts := strings.Split(string(data), ":")
+	if len(parts) != 2 {
+		return errors.New("point: expected X:Y")
+	}
+	x, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return err
+	}
+	y, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return err
+	}
+	p.X, p.Y = x, y
+	return nil
+}
+
+type customRecord struct {
+	Name  upperString `csv:"name"`
+	Point point       `csv:"point"`
+	Count int         `csv:"count"`
+}
+
+func TestDecodeStruct_CSVUnmarshaler(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(&customRecord{})
+	if err := decoder.ParseHeader([]string{"name", "point", "count"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	v, err := decoder.GetStruct([]string{"bob", "1:2", "5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := v.(*customRecord)
+	expect := &customRecord{Name: "BOB", Point: point{X: 1, Y: 2}, Count: 5}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expecting: %+v \nbut got: %+v", expect, got)
+	}
+}
+
+type money int64
+
+type withTypeDecoderRecord struct {
+	Amount money `csv:"amount"`
+}
+
+func TestDecodeStruct_WithTypeDecoder(t *testing.T) {
+	decoder := csv2struct.NewDecodeStruct(
+		&withTypeDecoderRecord{},
+		csv2struct.WithTypeDecoder(reflect.TypeOf(money(0)), func(s string, v reflect.Value) error {
+			i, err := strconv.ParseInt(strings.TrimPrefix(s, "$"), 10, 64)
+			if err != nil {
+				return err
+			}
+			v.SetInt(i)
+			return nil
+		}),
+	)
+	if err := decoder.ParseHeader([]string{"amount"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	v, err := decoder.GetStruct([]string{"$100"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := v.(*withTypeDecoderRecord)
+	if got.Amount != 100 {
+		t.Errorf("expecting amount 100 but got %d", got.Amount)
+	}
+}